@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import "time"
+
+// EventType distinguishes the kind of event a GenerateInfo describes.
+type EventType int
+
+const (
+	// EventGenerated fires after every Generate call, successful or not.
+	EventGenerated EventType = iota
+	// EventCounterOverflow fires in addition to EventGenerated whenever the
+	// in-millisecond counter wrapped and the factory had to bump its
+	// logical clock forward to keep ids monotonic.
+	EventCounterOverflow
+)
+
+// GenerateInfo describes a single Generate call to a registered callback.
+type GenerateInfo struct {
+	Type EventType
+
+	// Tsid is the id that was produced, or nil if Generate returned an
+	// error. Always nil on an EventCounterOverflow event.
+	Tsid *Tsid
+	Node int32
+	// UnixMillis is the time component embedded in Tsid, or zero if
+	// Generate returned an error. Always zero on an EventCounterOverflow
+	// event.
+	UnixMillis int64
+	// ClockAnomaly is true when the observed clock did not advance past
+	// the factory's last seen millisecond (a repeat, a backward drift or a
+	// leap second), so the time component was held steady instead of
+	// moving backwards.
+	ClockAnomaly bool
+	Duration     time.Duration
+	Err          error
+}
+
+// fireCallback invokes the registered callback, if any, once for the
+// generation itself and once more for a distinct counter overflow event
+// when one occurred. Must be called without f.mutex held.
+func (f *TsidFactory) fireCallback(result *generateResult, err error, duration time.Duration) {
+	if f.callback == nil {
+		return
+	}
+
+	if result != nil && result.counterOverflow {
+		f.callback(GenerateInfo{
+			Type:     EventCounterOverflow,
+			Node:     f.node,
+			Duration: duration,
+		})
+	}
+
+	info := GenerateInfo{
+		Type:     EventGenerated,
+		Node:     f.node,
+		Duration: duration,
+		Err:      err,
+	}
+	if result != nil {
+		info.Tsid = result.tsid
+		info.UnixMillis = result.tsid.GetUnixMillis()
+		info.ClockAnomaly = result.clockAnomaly
+	}
+
+	f.callback(info)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rushysloth/go-tsid/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithCallback(t *testing.T) {
+
+	t.Run("should fire for every generate call with timing and node info", func(t *testing.T) {
+
+		var events []GenerateInfo
+
+		intRandom := NewIntRandomWithSupplierFunc(func() (int32, error) {
+			return 0, nil
+		})
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithNode(7).
+			WithRandom(intRandom).
+			WithCallback(func(info GenerateInfo) {
+				events = append(events, info)
+			}).
+			NewInstance()
+		assert.NotNil(t, tsidFactory)
+
+		tsid, err := tsidFactory.Generate()
+		assert.Nil(t, err)
+
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventGenerated, events[0].Type)
+		assert.Equal(t, tsid, events[0].Tsid)
+		assert.Equal(t, int32(7), events[0].Node)
+		assert.Equal(t, tsid.GetUnixMillis(), events[0].UnixMillis)
+		assert.GreaterOrEqual(t, events[0].Duration, time.Duration(0))
+		assert.False(t, events[0].ClockAnomaly, "the very first Generate call must not be reported as a clock anomaly")
+	})
+
+	t.Run("given clock drift should report a clock anomaly", func(t *testing.T) {
+
+		var events []GenerateInfo
+
+		epoch := time.Now().UnixMilli()
+		mockClock := clock.NewFixedSequenceMockClock([]int64{epoch, epoch - 1})
+
+		intRandom := NewIntRandomWithSupplierFunc(func() (int32, error) {
+			return 0, nil
+		})
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithClock(mockClock).
+			WithRandom(intRandom).
+			WithCallback(func(info GenerateInfo) {
+				events = append(events, info)
+			}).
+			NewInstance()
+
+		_, _ = tsidFactory.Generate()
+		_, _ = tsidFactory.Generate()
+
+		assert.Len(t, events, 2)
+		assert.False(t, events[0].ClockAnomaly)
+		assert.True(t, events[1].ClockAnomaly)
+	})
+
+	t.Run("given counter overflow should also fire a distinct event", func(t *testing.T) {
+
+		var events []GenerateInfo
+
+		epoch := time.Now().UnixMilli()
+		mockClock := clock.NewFixedSequenceMockClock([]int64{epoch, epoch})
+
+		counterBits := RANDOM_BITS - NODE_BITS_1024
+		callCount := 0
+		intRandom := NewIntRandomWithSupplierFunc(func() (int32, error) {
+			callCount++
+			// Seed the counter one below its ceiling so the very next
+			// increment overflows it.
+			return (int32(1) << counterBits) - 1, nil
+		})
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithNodeBits(NODE_BITS_1024).
+			WithClock(mockClock).
+			WithRandom(intRandom).
+			WithCallback(func(info GenerateInfo) {
+				events = append(events, info)
+			}).
+			NewInstance()
+
+		_, _ = tsidFactory.Generate()
+		_, _ = tsidFactory.Generate()
+
+		assert.Len(t, events, 3) // generated, overflow, generated
+		assert.Equal(t, EventGenerated, events[0].Type)
+		assert.Equal(t, EventCounterOverflow, events[1].Type)
+		assert.Equal(t, EventGenerated, events[2].Type)
+	})
+}
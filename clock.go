@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import "github.com/rushysloth/go-tsid/clock"
+
+// Clock is the time source a TsidFactory reads the time component of a Tsid
+// from, plus the bits a callback needs to time a Generate call. It is
+// pluggable so tests can exercise clock drift and leap-second handling
+// deterministically. Promoted to the tsid/clock sub-package; kept as an
+// alias here so existing WithClock(Clock) callers keep compiling.
+type Clock = clock.Clock
+
+// NewRealClock returns a Clock backed by the system wall clock.
+func NewRealClock() Clock {
+	return clock.NewRealClock()
+}
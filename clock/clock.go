@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clock holds the pluggable time source a TsidFactory reads the
+// time component of a Tsid from, and a handful of implementations: a
+// real, wall-clock backed default; a monotonic variant immune to NTP steps
+// and leap seconds; and mocks for deterministic tests.
+package clock
+
+import "time"
+
+// Clock is the time source a TsidFactory reads the time component of a
+// Tsid from, plus the bits a callback needs to time a Generate call.
+type Clock interface {
+	UnixMilli() int64
+	Now() time.Time
+	Since(t time.Time) time.Duration
+}
+
+// RealClock is the default Clock, backed directly by the system wall
+// clock.
+type RealClock struct{}
+
+// NewRealClock returns a Clock backed by the system wall clock.
+func NewRealClock() *RealClock {
+	return &RealClock{}
+}
+
+func (c *RealClock) UnixMilli() int64 {
+	return time.Now().UnixMilli()
+}
+
+func (c *RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *RealClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// MonotonicClock bases UnixMilli off time.Now() taken once at construction
+// plus the monotonic delta since then, so it keeps advancing steadily even
+// across a wall-clock jump from an NTP step or leap second — the case the
+// TsidFactory drift handling otherwise has to paper over by holding its
+// time component steady.
+type MonotonicClock struct {
+	start      time.Time
+	startMilli int64
+}
+
+// NewMonotonicClock returns a Clock anchored to time.Now() at construction
+// time, advancing only via the runtime's monotonic clock reading from then
+// on.
+func NewMonotonicClock() *MonotonicClock {
+	start := time.Now()
+	return &MonotonicClock{start: start, startMilli: start.UnixMilli()}
+}
+
+func (c *MonotonicClock) UnixMilli() int64 {
+	return c.startMilli + time.Since(c.start).Milliseconds()
+}
+
+func (c *MonotonicClock) Now() time.Time {
+	return c.start.Add(time.Since(c.start))
+}
+
+func (c *MonotonicClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
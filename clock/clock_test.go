@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MonotonicClock(t *testing.T) {
+
+	t.Run("should never report a decreasing unix millis", func(t *testing.T) {
+		clock := NewMonotonicClock()
+
+		previous := clock.UnixMilli()
+		for i := 0; i < 1000; i++ {
+			current := clock.UnixMilli()
+			assert.GreaterOrEqual(t, current, previous)
+			previous = current
+		}
+	})
+}
+
+func Test_MockClock(t *testing.T) {
+
+	t.Run("fixed sequence mode should hand out millis in order", func(t *testing.T) {
+		clock := NewFixedSequenceMockClock([]int64{10, 20, 30})
+
+		assert.Equal(t, int64(10), clock.UnixMilli())
+		assert.Equal(t, int64(20), clock.UnixMilli())
+		assert.Equal(t, int64(30), clock.UnixMilli())
+	})
+
+	t.Run("programmable advance mode should only move on Advance", func(t *testing.T) {
+		clock := NewProgrammableMockClock(100)
+
+		assert.Equal(t, int64(100), clock.UnixMilli())
+		assert.Equal(t, int64(100), clock.UnixMilli())
+
+		clock.Advance(5)
+		assert.Equal(t, int64(105), clock.UnixMilli())
+	})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// MockClock is a deterministic Clock for tests. It runs in one of two
+// modes: a fixed sequence of millis values handed out in order (useful for
+// scripting clock drift and leap-second scenarios), or a programmable
+// current value that only moves when the test calls Advance (useful when
+// the exact number of UnixMilli calls isn't known up front).
+type MockClock struct {
+	mu sync.Mutex
+
+	millis []int64
+	index  int
+
+	advanceMode bool
+	current     int64
+}
+
+// NewFixedSequenceMockClock returns a MockClock that hands out millis, in
+// order, one per UnixMilli call.
+func NewFixedSequenceMockClock(millis []int64) *MockClock {
+	return &MockClock{millis: millis}
+}
+
+// NewProgrammableMockClock returns a MockClock starting at start that only
+// advances when Advance is called.
+func NewProgrammableMockClock(start int64) *MockClock {
+	return &MockClock{current: start, advanceMode: true}
+}
+
+// Advance moves a programmable-advance MockClock forward by delta millis.
+// It is a no-op on a fixed-sequence MockClock.
+func (c *MockClock) Advance(delta int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.advanceMode {
+		c.current += delta
+	}
+}
+
+func (c *MockClock) UnixMilli() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.advanceMode {
+		return c.current
+	}
+
+	millis := c.millis[c.index]
+	c.index++
+
+	return millis
+}
+
+// Now and Since are independent of UnixMilli's scripted sequence: they
+// exist only so a caller timing an operation (e.g. a Generate callback)
+// gets a usable clock, not to drive whatever tick logic is under test, so
+// they must not advance index themselves.
+func (c *MockClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *MockClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
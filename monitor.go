@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import (
+	"sync"
+	"time"
+)
+
+// monitorEmaAlpha weights the most recent sample against the running
+// average, matching the smoothing factor the flowcontrol.Monitor pattern
+// uses for its rate EMA.
+const monitorEmaAlpha = 0.3
+
+// MonitorStatus is a point-in-time snapshot returned by
+// GenerationMonitor.Status.
+type MonitorStatus struct {
+	// Count is the total number of ids observed since the monitor started.
+	Count uint64
+	// Active is how long the monitor has been sampling.
+	Active time.Duration
+	// RatePerSec is the instantaneous rate measured over the last sample
+	// window.
+	RatePerSec float64
+	// EmaRatePerSec is the exponentially-weighted moving average of
+	// RatePerSec across all sample windows.
+	EmaRatePerSec float64
+	// CounterFill is how close the factory is to exhausting its
+	// per-millisecond counter budget, in the range [0, 1].
+	CounterFill float64
+}
+
+// GenerationMonitor tracks sampled throughput for a TsidFactory: ids per
+// second over a sliding window, an EMA of that rate, the running total and
+// how full the in-millisecond counter space currently is. Samples are taken
+// on every successful Generate call but batched under a single mutex so the hot path
+// only pays for a lock and a few arithmetic ops.
+type GenerationMonitor struct {
+	mu sync.Mutex
+
+	started   time.Time
+	count     uint64
+	windowAt  time.Time
+	windowHit uint64
+
+	rate        float64
+	ema         float64
+	hasEma      bool
+	counterFill float64
+}
+
+// NewGenerationMonitor returns a GenerationMonitor ready to be attached to a
+// TsidFactory via WithMonitor.
+func NewGenerationMonitor() *GenerationMonitor {
+	return &GenerationMonitor{}
+}
+
+// sample records one generated id and, once at least a millisecond has
+// elapsed since the last window boundary, rolls the sliding window and
+// updates the EMA.
+func (m *GenerationMonitor) sample(counterFill float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if m.started.IsZero() {
+		m.started = now
+		m.windowAt = now
+	}
+
+	m.count++
+	m.windowHit++
+	m.counterFill = counterFill
+
+	elapsed := now.Sub(m.windowAt)
+	if elapsed < time.Millisecond {
+		return
+	}
+
+	m.rate = float64(m.windowHit) / elapsed.Seconds()
+	if !m.hasEma {
+		m.ema = m.rate
+		m.hasEma = true
+	} else {
+		m.ema = monitorEmaAlpha*m.rate + (1-monitorEmaAlpha)*m.ema
+	}
+
+	m.windowAt = now
+	m.windowHit = 0
+}
+
+// Status returns a snapshot of the monitor's current state.
+func (m *GenerationMonitor) Status() MonitorStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := time.Duration(0)
+	if !m.started.IsZero() {
+		active = time.Since(m.started)
+	}
+
+	return MonitorStatus{
+		Count:         m.count,
+		Active:        active,
+		RatePerSec:    m.rate,
+		EmaRatePerSec: m.ema,
+		CounterFill:   m.counterFill,
+	}
+}
+
+// Reset clears all accumulated statistics.
+func (m *GenerationMonitor) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started = time.Time{}
+	m.count = 0
+	m.windowAt = time.Time{}
+	m.windowHit = 0
+	m.rate = 0
+	m.ema = 0
+	m.hasEma = false
+	m.counterFill = 0
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithMonitor(t *testing.T) {
+
+	t.Run("should track total count and counter fill across generate calls", func(t *testing.T) {
+
+		monitor := NewGenerationMonitor()
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithNodeBits(NODE_BITS_1024).
+			WithMonitor(monitor).
+			NewInstance()
+
+		for i := 0; i < 5; i++ {
+			_, err := tsidFactory.Generate()
+			assert.Nil(t, err)
+		}
+
+		status := monitor.Status()
+		assert.Equal(t, uint64(5), status.Count)
+		assert.GreaterOrEqual(t, status.CounterFill, 0.0)
+		assert.LessOrEqual(t, status.CounterFill, 1.0)
+	})
+
+	t.Run("should report a positive rate and update the ema once the sampling window rolls over", func(t *testing.T) {
+
+		monitor := NewGenerationMonitor()
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithMonitor(monitor).
+			NewInstance()
+
+		_, err := tsidFactory.Generate()
+		assert.Nil(t, err)
+
+		time.Sleep(2 * time.Millisecond)
+		_, err = tsidFactory.Generate()
+		assert.Nil(t, err)
+
+		status := monitor.Status()
+		assert.Greater(t, status.RatePerSec, 0.0)
+		assert.Equal(t, status.RatePerSec, status.EmaRatePerSec, "the first window should seed the ema directly")
+
+		firstRate := status.RatePerSec
+
+		time.Sleep(2 * time.Millisecond)
+		_, err = tsidFactory.Generate()
+		assert.Nil(t, err)
+
+		status = monitor.Status()
+		expectedEma := monitorEmaAlpha*status.RatePerSec + (1-monitorEmaAlpha)*firstRate
+		assert.InDelta(t, expectedEma, status.EmaRatePerSec, 1e-9)
+	})
+
+	t.Run("reset should clear accumulated statistics", func(t *testing.T) {
+
+		monitor := NewGenerationMonitor()
+
+		tsidFactory, _ := TsidFactoryBuilder().
+			WithMonitor(monitor).
+			NewInstance()
+
+		_, _ = tsidFactory.Generate()
+		assert.Equal(t, uint64(1), monitor.Status().Count)
+
+		monitor.Reset()
+		assert.Equal(t, uint64(0), monitor.Status().Count)
+		assert.Equal(t, time.Duration(0), monitor.Status().Active)
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import "math/rand"
+
+// IRandom is the source of randomness a TsidFactory draws its counter seed
+// from whenever the clock ticks forward into a new millisecond.
+type IRandom interface {
+	NextInt() (int32, error)
+}
+
+// IRandomSupplier supplies raw int32 values to an IRandom implementation.
+type IRandomSupplier interface {
+	NextInt32() (int32, error)
+}
+
+type mathRandomSupplier struct{}
+
+// NewMathRandomSupplier returns an IRandomSupplier backed by math/rand.
+func NewMathRandomSupplier() IRandomSupplier {
+	return &mathRandomSupplier{}
+}
+
+func (s *mathRandomSupplier) NextInt32() (int32, error) {
+	return rand.Int31(), nil
+}
+
+type intRandom struct {
+	supplier IRandomSupplier
+}
+
+// NewIntRandom returns an IRandom that draws its values from the given
+// supplier.
+func NewIntRandom(supplier IRandomSupplier) IRandom {
+	return &intRandom{supplier: supplier}
+}
+
+func (r *intRandom) NextInt() (int32, error) {
+	return r.supplier.NextInt32()
+}
+
+type intRandomSupplierFunc struct {
+	fn func() (int32, error)
+}
+
+// NewIntRandomWithSupplierFunc returns an IRandom backed directly by the
+// given function, useful for tests and fixtures that need a deterministic
+// or otherwise hand-rolled sequence.
+func NewIntRandomWithSupplierFunc(fn func() (int32, error)) IRandom {
+	return &intRandomSupplierFunc{fn: fn}
+}
+
+func (r *intRandomSupplierFunc) NextInt() (int32, error) {
+	return r.fn()
+}
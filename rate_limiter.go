@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket paces callers to at most capacity takes per millisecond,
+// refilling at the start of every millisecond observed on its clock. It is
+// the leaky/token bucket borrowed from the uber-go/ratelimit design, adapted
+// to TSID's natural per-millisecond counter budget.
+type tokenBucket struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	clock    Clock
+	capacity int32
+	tokens   int32
+	millis   int64
+
+	waited   uint64
+	advanced uint64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+	closed    bool
+}
+
+// newTokenBucket returns a tokenBucket capped at maxPerMilli takes per
+// millisecond, further clamped to counterSpace (2^counter_bits) since that
+// is the hard ceiling the factory's counter can represent. The returned
+// bucket owns a background goroutine that must be stopped with close once
+// the bucket is no longer needed.
+func newTokenBucket(maxPerMilli int32, counterSpace int32, clock Clock) *tokenBucket {
+	capacity := maxPerMilli
+	if counterSpace < capacity {
+		capacity = counterSpace
+	}
+
+	b := &tokenBucket{clock: clock, capacity: capacity, stop: make(chan struct{})}
+	b.cond = sync.NewCond(&b.mu)
+
+	go b.refillLoop()
+
+	return b
+}
+
+// refillLoop wakes once a millisecond to refill the bucket and release any
+// callers parked in take(), until close stops it.
+func (b *tokenBucket) refillLoop() {
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			b.refillLocked()
+			b.mu.Unlock()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// close stops the bucket's background refill goroutine and wakes any
+// callers parked in take(). Safe to call more than once.
+func (b *tokenBucket) close() {
+	b.closeOnce.Do(func() {
+		close(b.stop)
+
+		b.mu.Lock()
+		b.closed = true
+		b.cond.Broadcast()
+		b.mu.Unlock()
+	})
+}
+
+func (b *tokenBucket) refillLocked() {
+	now := b.clock.UnixMilli()
+	if now == b.millis {
+		return
+	}
+
+	b.millis = now
+	b.tokens = b.capacity
+	b.advanced++
+	b.cond.Broadcast()
+}
+
+// take blocks until a token is available in the current millisecond, or
+// returns immediately if the bucket has been closed.
+func (b *tokenBucket) take() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.refillLocked()
+
+	if b.tokens > 0 {
+		b.tokens--
+		return
+	}
+
+	b.waited++
+	for b.tokens == 0 && !b.closed {
+		b.cond.Wait()
+	}
+	if b.tokens > 0 {
+		b.tokens--
+	}
+}
+
+func (b *tokenBucket) waitedCount() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.waited
+}
+
+func (b *tokenBucket) advancedCount() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.advanced
+}
+
+// RateLimitedFactory wraps an already built TsidFactory with the same
+// per-millisecond token bucket pacing that WithRateLimit configures on the
+// builder. It exists for callers that already hold a *TsidFactory (e.g.
+// shared across packages) and want to bolt rate limiting on afterwards
+// rather than rebuilding it.
+type RateLimitedFactory struct {
+	factory *TsidFactory
+	limiter *tokenBucket
+}
+
+// NewRateLimitedFactory wraps factory so that Generate blocks once more than
+// maxPerMilli ids have been produced within the same millisecond.
+func NewRateLimitedFactory(factory *TsidFactory, maxPerMilli int) *RateLimitedFactory {
+	counterSpace := int32(1) << factory.counterBits
+
+	return &RateLimitedFactory{
+		factory: factory,
+		limiter: newTokenBucket(int32(maxPerMilli), counterSpace, factory.clock),
+	}
+}
+
+// Generate blocks until the current millisecond's budget has room, then
+// delegates to the wrapped factory.
+func (f *RateLimitedFactory) Generate() (*Tsid, error) {
+	f.limiter.take()
+	return f.factory.Generate()
+}
+
+// Waited returns how many Generate calls had to block for the bucket to
+// refill.
+func (f *RateLimitedFactory) Waited() uint64 {
+	return f.limiter.waitedCount()
+}
+
+// Advanced returns how many times the bucket refilled because the clock
+// ticked into a new millisecond.
+func (f *RateLimitedFactory) Advanced() uint64 {
+	return f.limiter.advancedCount()
+}
+
+// Close stops the background goroutine backing this factory's rate
+// limiter. Safe to call more than once.
+func (f *RateLimitedFactory) Close() {
+	f.limiter.close()
+}
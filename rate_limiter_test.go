@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithRateLimit(t *testing.T) {
+
+	t.Run("given rate limit higher than counter space should clamp bucket to it", func(t *testing.T) {
+		bucket := newTokenBucket(1_000_000, 1<<NODE_BITS_1024, NewRealClock())
+		defer bucket.close()
+		assert.Equal(t, int32(1<<NODE_BITS_1024), bucket.capacity)
+	})
+
+	t.Run("given burst past the per-millisecond budget should block and record waits", func(t *testing.T) {
+		tsidFactory, err := TsidFactoryBuilder().
+			WithRateLimit(5).
+			NewInstance()
+		assert.Nil(t, err)
+		assert.NotNil(t, tsidFactory)
+		defer tsidFactory.Close()
+
+		for i := 0; i < 50; i++ {
+			tsid, err := tsidFactory.Generate()
+			assert.Nil(t, err)
+			assert.NotNil(t, tsid)
+		}
+
+		assert.Greater(t, tsidFactory.Waited(), uint64(0))
+		assert.Greater(t, tsidFactory.Advanced(), uint64(0))
+	})
+}
+
+func Test_NewRateLimitedFactory(t *testing.T) {
+
+	t.Run("should pace an existing factory the same way WithRateLimit does", func(t *testing.T) {
+		tsidFactory, err := TsidFactoryBuilder().NewInstance()
+		assert.Nil(t, err)
+
+		limited := NewRateLimitedFactory(tsidFactory, 5)
+		defer limited.Close()
+
+		for i := 0; i < 50; i++ {
+			tsid, err := limited.Generate()
+			assert.Nil(t, err)
+			assert.NotNil(t, tsid)
+		}
+
+		assert.Greater(t, limited.Waited(), uint64(0))
+	})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import "strings"
+
+const (
+	// TSID_EPOCH is the custom epoch (2020-01-01T00:00:00Z) that the time
+	// component of every Tsid is measured against, in unix millis.
+	TSID_EPOCH int64 = 1577836800000
+
+	// RANDOM_BITS is the number of bits reserved for the random/node/counter
+	// component of a Tsid, leaving the remaining 42 bits for the time
+	// component.
+	RANDOM_BITS int32 = 22
+	RANDOM_MASK int32 = 0x3FFFFF
+
+	// NODE_BITS_256, NODE_BITS_1024 and NODE_BITS_4096 are convenience node
+	// bit widths for the common cluster sizes of 256, 1024 and 4096 nodes.
+	NODE_BITS_256  int32 = 8
+	NODE_BITS_1024 int32 = 10
+	NODE_BITS_4096 int32 = 12
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Tsid is a 64 bit, time-sorted unique identifier composed of a 42 bit time
+// component and a 22 bit random component (further split into node and
+// counter bits by the TsidFactory that produced it).
+type Tsid struct {
+	number int64
+}
+
+// GetRandom returns the full 22 bit random component of this Tsid, including
+// any node and counter bits packed into it.
+func (t *Tsid) GetRandom() int32 {
+	return int32(t.number) & RANDOM_MASK
+}
+
+// GetUnixMillis returns the time component of this Tsid as a unix millis
+// timestamp.
+func (t *Tsid) GetUnixMillis() int64 {
+	return (t.number >> RANDOM_BITS) + TSID_EPOCH
+}
+
+// ToString encodes this Tsid as a 13 character, Crockford base32 string.
+func (t *Tsid) ToString() string {
+	number := uint64(t.number)
+	chars := make([]byte, 13)
+	for i := 12; i >= 0; i-- {
+		chars[i] = crockfordAlphabet[number&0x1F]
+		number >>= 5
+	}
+	return strings.ToUpper(string(chars))
+}
+
+var defaultFactory *TsidFactory
+
+func init() {
+	defaultFactory, _ = TsidFactoryBuilder().NewInstance()
+}
+
+// Fast generates a Tsid using a shared, package level factory configured
+// with every default. It is a convenience for callers that don't need to
+// customize node id, node bits, random source or clock.
+func Fast() *Tsid {
+	tsid, _ := defaultFactory.Generate()
+	return tsid
+}
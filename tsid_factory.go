@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+import "sync"
+
+// TsidFactory generates Tsid values for a single node, keeping track of the
+// last millisecond observed on its Clock and the in-millisecond counter
+// needed to tell ids produced in the same millisecond apart.
+type TsidFactory struct {
+	node        int32
+	nodeBits    int32
+	counterBits int32
+	counter     int32
+	lastMillis  int64
+
+	clock  Clock
+	random IRandom
+
+	limiter  *tokenBucket
+	callback func(GenerateInfo)
+	monitor  *GenerationMonitor
+
+	mutex sync.Mutex
+}
+
+// Generate produces the next Tsid for this factory. Calls are safe for
+// concurrent use. If the factory was built with WithRateLimit, Generate
+// blocks until the current millisecond's budget has room for another id. If
+// the factory was built with WithCallback, the callback fires after every
+// call, including failed ones.
+func (f *TsidFactory) Generate() (*Tsid, error) {
+	if f.limiter != nil {
+		f.limiter.take()
+	}
+
+	start := f.clock.Now()
+
+	f.mutex.Lock()
+	result, err := f.generateLocked()
+	f.mutex.Unlock()
+
+	f.fireCallback(result, err, f.clock.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.monitor != nil {
+		f.monitor.sample(result.counterFill)
+	}
+
+	return result.tsid, nil
+}
+
+// generateResult carries generateLocked's output plus the bookkeeping
+// needed to describe the call to a registered callback or monitor.
+type generateResult struct {
+	tsid            *Tsid
+	clockAnomaly    bool
+	counterOverflow bool
+	counterFill     float64
+}
+
+// generateLocked does the actual work of Generate and must be called with
+// f.mutex held.
+func (f *TsidFactory) generateLocked() (*generateResult, error) {
+	now := f.clock.UnixMilli()
+	result := &generateResult{}
+
+	switch {
+	case now > f.lastMillis:
+		f.lastMillis = now
+		counter, err := f.random.NextInt()
+		if err != nil {
+			return nil, err
+		}
+		f.counter = counter & f.counterMask()
+	default:
+		// Clock repeated, drifted backwards or hit a leap second: never let
+		// the time component decrease, just keep ticking the counter.
+		result.clockAnomaly = true
+		f.counter = (f.counter + 1) & f.counterMask()
+		if f.counter == 0 {
+			// Counter space exhausted for this millisecond, borrow the next one.
+			f.lastMillis++
+			result.counterOverflow = true
+		}
+	}
+
+	random := (f.node << f.counterBits) | f.counter
+	number := (f.lastMillis-TSID_EPOCH)<<RANDOM_BITS | int64(random&RANDOM_MASK)
+
+	result.tsid = &Tsid{number: number}
+	result.counterFill = float64(f.counter) / float64(f.counterMask())
+	return result, nil
+}
+
+func (f *TsidFactory) counterMask() int32 {
+	return int32(1)<<f.counterBits - 1
+}
+
+// Waited returns how many Generate calls had to block waiting for the
+// per-millisecond rate limit budget to refill. Always zero unless the
+// factory was built with WithRateLimit.
+func (f *TsidFactory) Waited() uint64 {
+	if f.limiter == nil {
+		return 0
+	}
+	return f.limiter.waitedCount()
+}
+
+// Advanced returns how many times the rate limiter's token bucket refilled
+// because the clock ticked into a new millisecond. Always zero unless the
+// factory was built with WithRateLimit.
+func (f *TsidFactory) Advanced() uint64 {
+	if f.limiter == nil {
+		return 0
+	}
+	return f.limiter.advancedCount()
+}
+
+// Close stops the background goroutine backing a rate limiter configured
+// via WithRateLimit. It is a no-op if the factory was not rate limited.
+// Safe to call more than once.
+func (f *TsidFactory) Close() {
+	if f.limiter != nil {
+		f.limiter.close()
+	}
+}
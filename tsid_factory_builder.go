@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2023
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsid
+
+type tsidFactoryBuilder struct {
+	node      int32
+	nodeBits  int32
+	random    IRandom
+	clock     Clock
+	rateLimit int32
+	callback  func(GenerateInfo)
+	monitor   *GenerationMonitor
+}
+
+// TsidFactoryBuilder starts a fluent chain used to configure and build a
+// TsidFactory.
+func TsidFactoryBuilder() *tsidFactoryBuilder {
+	return &tsidFactoryBuilder{}
+}
+
+// WithNode sets the node id embedded in every Tsid produced by the factory.
+// Defaults to zero.
+func (b *tsidFactoryBuilder) WithNode(node int32) *tsidFactoryBuilder {
+	b.node = node
+	return b
+}
+
+// WithNodeBits sets how many of the 22 random bits are reserved for the node
+// id, leaving the rest for the in-millisecond counter. Defaults to zero.
+func (b *tsidFactoryBuilder) WithNodeBits(nodeBits int32) *tsidFactoryBuilder {
+	b.nodeBits = nodeBits
+	return b
+}
+
+// WithRandom sets the random source used to seed the counter whenever the
+// clock ticks into a new millisecond. Defaults to a math/rand backed
+// IRandom.
+func (b *tsidFactoryBuilder) WithRandom(random IRandom) *tsidFactoryBuilder {
+	b.random = random
+	return b
+}
+
+// WithClock sets the Clock the factory reads the time component from.
+// Defaults to the system wall clock.
+func (b *tsidFactoryBuilder) WithClock(clock Clock) *tsidFactoryBuilder {
+	b.clock = clock
+	return b
+}
+
+// WithRateLimit caps Generate() to at most maxPerMilli ids per millisecond
+// (clamped to the factory's counter space, i.e. 2^counter_bits), blocking
+// callers that would exceed it until the next millisecond instead of
+// returning an error. The counter still starts each millisecond at a random
+// value, so it can wrap, and the time component move forward by a
+// millisecond, before maxPerMilli ids have been produced.
+func (b *tsidFactoryBuilder) WithRateLimit(maxPerMilli int) *tsidFactoryBuilder {
+	b.rateLimit = int32(maxPerMilli)
+	return b
+}
+
+// WithCallback registers a hook that fires after every Generate call with
+// the produced Tsid, the unix millis of its time component, whether a clock drift or
+// leap second forced the time component to hold steady, the call's
+// duration and any error. It also fires a distinct EventCounterOverflow
+// event whenever the in-millisecond counter wraps and the logical clock had
+// to be bumped forward.
+func (b *tsidFactoryBuilder) WithCallback(callback func(GenerateInfo)) *tsidFactoryBuilder {
+	b.callback = callback
+	return b
+}
+
+// WithMonitor attaches a GenerationMonitor that samples every successful Generate call
+// to expose throughput statistics: ids/sec, an EMA of that rate, the
+// running total and how full the in-millisecond counter space currently is.
+func (b *tsidFactoryBuilder) WithMonitor(monitor *GenerationMonitor) *tsidFactoryBuilder {
+	b.monitor = monitor
+	return b
+}
+
+// NewInstance builds the configured TsidFactory.
+func (b *tsidFactoryBuilder) NewInstance() (*TsidFactory, error) {
+	clock := b.clock
+	if clock == nil {
+		clock = NewRealClock()
+	}
+
+	random := b.random
+	if random == nil {
+		random = NewIntRandom(NewMathRandomSupplier())
+	}
+
+	counterBits := RANDOM_BITS - b.nodeBits
+
+	factory := &TsidFactory{
+		node:        b.node,
+		nodeBits:    b.nodeBits,
+		counterBits: counterBits,
+		lastMillis:  -1,
+		clock:       clock,
+		random:      random,
+		callback:    b.callback,
+		monitor:     b.monitor,
+	}
+
+	if b.rateLimit > 0 {
+		factory.limiter = newTokenBucket(b.rateLimit, int32(1)<<counterBits, clock)
+	}
+
+	return factory, nil
+}
+
+// Build is an alias of NewInstance kept for callers that prefer the more
+// conventional builder verb.
+func (b *tsidFactoryBuilder) Build() (*TsidFactory, error) {
+	return b.NewInstance()
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rushysloth/go-tsid/clock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -149,16 +150,16 @@ func Test_Generate(t *testing.T) {
 		var diff int64 = 10000
 		epoch := time.Now().UnixMilli()
 
-		clock := &MockClock{
-			millis: []int64{-1, epoch, epoch + 0, epoch + 1, epoch + 2, epoch + 3 - diff, epoch + 4 - diff, epoch + 5},
-		}
+		mockClock := clock.NewFixedSequenceMockClock(
+			[]int64{epoch, epoch + 0, epoch + 1, epoch + 2, epoch + 3 - diff, epoch + 4 - diff, epoch + 5},
+		)
 
 		intRandom := NewIntRandomWithSupplierFunc(func() (int32, error) {
 			return 0, nil
 		})
 
 		tsidFactory, _ := TsidFactoryBuilder().
-			WithClock(clock).
+			WithClock(mockClock).
 			WithRandom(intRandom).
 			NewInstance()
 		assert.NotNil(t, tsidFactory)
@@ -199,16 +200,16 @@ func Test_Generate(t *testing.T) {
 		second := time.Now().Second()
 		leapSecond := second - 1
 
-		clock := &MockClock{
-			millis: []int64{-1, int64(second * 1000), int64(leapSecond * 1000)},
-		}
+		mockClock := clock.NewFixedSequenceMockClock(
+			[]int64{int64(second * 1000), int64(leapSecond * 1000)},
+		)
 
 		intRandom := NewIntRandomWithSupplierFunc(func() (int32, error) {
 			return 0, nil
 		})
 
 		tsidFactory, _ := TsidFactoryBuilder().
-			WithClock(clock).
+			WithClock(mockClock).
 			WithRandom(intRandom).
 			NewInstance()
 		assert.NotNil(t, tsidFactory)
@@ -223,15 +224,3 @@ func Test_Generate(t *testing.T) {
 		assert.Equal(t, ms1, ms2) // time component should not decrease due to leap second
 	})
 }
-
-type MockClock struct {
-	index  int
-	millis []int64
-}
-
-func (c *MockClock) UnixMilli() int64 {
-	millis := c.millis[c.index]
-	c.index++
-
-	return millis
-}